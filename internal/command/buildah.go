@@ -0,0 +1,89 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/faradey/fyne-cross/internal/log"
+	"github.com/faradey/fyne-cross/internal/volume"
+	"golang.org/x/sys/execabs"
+)
+
+// buildahImage drives a single buildah working container. Unlike docker and
+// podman, buildah has no daemon: the working container is created lazily, on
+// the first Run, with `buildah from`, and every subsequent Run is issued
+// against that same working container with `buildah run`, which manages its
+// own user namespace so no outer `buildah unshare` is needed.
+type buildahImage struct {
+	binary string
+	id     string
+	image  string
+	ctrID  string
+	env    map[string]string
+}
+
+// newBuildahImage returns the containerImage driving image through buildah.
+// The working container itself isn't created until the first Run.
+func newBuildahImage(binary, id, image string) containerImage {
+	return &buildahImage{
+		binary: binary,
+		id:     id,
+		image:  image,
+		env:    map[string]string{},
+	}
+}
+
+// ID returns the image id used to namespace host paths, as set up by the container engine
+func (i *buildahImage) ID() string {
+	return i.id
+}
+
+// SetEnv sets an environment variable forwarded to every subsequent Run
+func (i *buildahImage) SetEnv(key, value string) {
+	i.env[key] = value
+}
+
+// Run executes args against the working container, bind-mounting vol's work dir
+func (i *buildahImage) Run(vol volume.Volume, opts options, args []string) error {
+	if i.ctrID == "" {
+		out, err := execabs.Command(i.binary, "from", i.image).Output()
+		if err != nil {
+			return fmt.Errorf("could not create buildah working container from %s: %v", i.image, err)
+		}
+		i.ctrID = strings.TrimSpace(string(out))
+	}
+
+	workDir := opts.WorkDir
+	if workDir == "" {
+		workDir = vol.WorkDirContainer()
+	}
+
+	runArgs := []string{"run",
+		"--mount", fmt.Sprintf("type=bind,src=%s,dst=%s", vol.WorkDirHost(), vol.WorkDirContainer()),
+		"--workingdir", workDir,
+	}
+
+	for k, v := range i.env {
+		runArgs = append(runArgs, "-e", fmt.Sprintf("%s=%s", k, v))
+	}
+
+	runArgs = append(runArgs, i.ctrID, "--")
+	runArgs = append(runArgs, args...)
+
+	log.Infof("[i] buildah %s", strings.Join(runArgs, " "))
+
+	out, err := execabs.Command(i.binary, runArgs...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("could not run command in buildah container: %s: %v", out, err)
+	}
+
+	return nil
+}
+
+// Close removes the working container created for this image with `buildah rm`
+func (i *buildahImage) Close() error {
+	if i.ctrID == "" {
+		return nil
+	}
+	return execabs.Command(i.binary, "rm", i.ctrID).Run()
+}
@@ -0,0 +1,126 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/faradey/fyne-cross/internal/log"
+	"github.com/faradey/fyne-cross/internal/volume"
+	"golang.org/x/sys/execabs"
+)
+
+// options customizes a single containerImage.Run invocation
+type options struct {
+	// WorkDir overrides the container working directory for this Run
+	WorkDir string
+}
+
+// containerImage is a single OS/arch build environment driven by the
+// selected container engine
+type containerImage interface {
+	// ID returns the image identifier used to namespace host paths (e.g. "amd64")
+	ID() string
+	// SetEnv sets an environment variable forwarded to every subsequent Run
+	SetEnv(key, value string)
+	// Run executes args inside the image, bind-mounting vol and honouring opts
+	Run(vol volume.Volume, opts options, args []string) error
+	// Close releases any resource the image held open across Run calls
+	// (e.g. buildah's working container). Safe to call even if Run was
+	// never called.
+	Close() error
+}
+
+// containerEngine drives the engine selected on Context to create containerImages
+type containerEngine struct {
+	engine Engine
+}
+
+// newContainerEngine returns the runner driving ctx's selected engine
+func newContainerEngine(ctx Context) (*containerEngine, error) {
+	return &containerEngine{engine: ctx.Engine}, nil
+}
+
+// createContainerImage returns the containerImage for arch/OS backed by
+// image, dispatching to the concrete implementation for the selected engine
+func (r *containerEngine) createContainerImage(arch Architecture, OS string, image string) containerImage {
+	id := string(arch)
+
+	if r.engine.IsBuildah() {
+		return newBuildahImage(r.engine.Binary, id, image)
+	}
+
+	return newDockerImage(r.engine, id, image)
+}
+
+// dockerImage drives docker, podman and nerdctl, which all accept the same
+// `<binary> run -v ... -w ... -e ... <image> <args>` invocation
+type dockerImage struct {
+	engine Engine
+	id     string
+	image  string
+	env    map[string]string
+}
+
+// newDockerImage returns the containerImage driving image through engine's
+// docker-compatible CLI (docker, podman or nerdctl)
+func newDockerImage(engine Engine, id, image string) containerImage {
+	return &dockerImage{engine: engine, id: id, image: image, env: map[string]string{}}
+}
+
+// ID returns the image id used to namespace host paths, as set up by the container engine
+func (i *dockerImage) ID() string {
+	return i.id
+}
+
+// SetEnv sets an environment variable forwarded to every subsequent Run
+func (i *dockerImage) SetEnv(key, value string) {
+	i.env[key] = value
+}
+
+// Run executes args inside a throwaway container started from image,
+// bind-mounting vol's work dir. For podman, the engine's resolved remote
+// connection (if any) is prepended so the run targets that connection.
+func (i *dockerImage) Run(vol volume.Volume, opts options, args []string) error {
+	workDir := opts.WorkDir
+	if workDir == "" {
+		workDir = vol.WorkDirContainer()
+	}
+
+	runArgs := append([]string{}, i.engine.RemoteArgs()...)
+	runArgs = append(runArgs, "run", "--rm",
+		"-v", fmt.Sprintf("%s:%s", vol.WorkDirHost(), vol.WorkDirContainer()),
+		"-w", workDir,
+	)
+
+	for k, v := range i.env {
+		runArgs = append(runArgs, "-e", fmt.Sprintf("%s=%s", k, v))
+	}
+
+	runArgs = append(runArgs, i.image)
+	runArgs = append(runArgs, args...)
+
+	log.Infof("[i] %s %s", i.engine, strings.Join(runArgs, " "))
+
+	out, err := execabs.Command(i.engine.Binary, runArgs...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("could not run command in %s container: %s: %v", i.engine, out, err)
+	}
+
+	return nil
+}
+
+// Close is a no-op: dockerImage.Run starts throwaway containers with --rm,
+// so there's nothing left open across Run calls to release.
+func (i *dockerImage) Close() error {
+	return nil
+}
+
+// closeImages releases the resources every image in images is holding open,
+// logging rather than failing the build on a teardown error
+func closeImages(images []containerImage) {
+	for _, image := range images {
+		if err := image.Close(); err != nil {
+			log.Infof("[i] could not release container image %s: %v", image.ID(), err)
+		}
+	}
+}
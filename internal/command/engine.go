@@ -1,8 +1,11 @@
 package command
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"golang.org/x/sys/execabs"
@@ -13,11 +16,44 @@ const (
 	dockerEngine     = "docker"
 	podmanEngine     = "podman"
 	kubernetesEngine = "kubernetes"
+	buildahEngine    = "buildah"
+	nerdctlEngine    = "nerdctl"
 )
 
 type Engine struct {
 	Name   string
 	Binary string
+
+	// URL is the remote podman service destination (e.g. ssh://user@host/run/user/1000/podman/podman.sock),
+	// resolved from PODMAN_HOST/CONTAINER_HOST or --podman-connection. Empty for a local engine.
+	URL string
+	// Identity is the ssh private key to use with URL, when it names an ssh:// destination
+	Identity string
+}
+
+// PodmanRemote configures the remote podman connection MakeEngine should
+// resolve. Host, set from --podman-host, takes precedence over
+// PODMAN_HOST/CONTAINER_HOST; Connection, set from --podman-connection, names
+// a [engine.service_destinations] entry in containers.conf and is only
+// consulted when neither Host nor the env vars are set.
+type PodmanRemote struct {
+	Host       string
+	Connection string
+}
+
+// RemoteArgs returns the --url/--identity arguments to prepend to every
+// podman invocation so it targets the resolved remote connection, or nil
+// for a local engine
+func (e Engine) RemoteArgs() []string {
+	if e.URL == "" {
+		return nil
+	}
+
+	args := []string{"--url=" + e.URL}
+	if e.Identity != "" {
+		args = append(args, "--identity="+e.Identity)
+	}
+	return args
 }
 
 func (e Engine) String() string {
@@ -36,8 +72,34 @@ func (e Engine) IsKubernetes() bool {
 	return e.Name == kubernetesEngine
 }
 
-// MakeEngine returns a new container engine. Pass empty string to autodetect
-func MakeEngine(e string) (Engine, error) {
+func (e Engine) IsBuildah() bool {
+	return e.Name == buildahEngine
+}
+
+func (e Engine) IsNerdctl() bool {
+	return e.Name == nerdctlEngine
+}
+
+// MakeEngine returns a new container engine. Pass empty string to autodetect.
+// podman optionally configures a remote podman connection (see PodmanRemote);
+// it is ignored for every engine other than podman.
+//
+// Engine selection follows this precedence: FYNE_CROSS_ENGINE env var, then
+// the explicit e argument, then autodetection in the order docker, podman,
+// nerdctl. Within autodetection, a configured remote podman connection is
+// preferred over the local docker binary, since it signals the user wants
+// podman even when a local docker/podman binary with no running service is
+// also present.
+func MakeEngine(e string, podman ...PodmanRemote) (Engine, error) {
+	var remote PodmanRemote
+	if len(podman) > 0 {
+		remote = podman[0]
+	}
+
+	if envEngine := os.Getenv("FYNE_CROSS_ENGINE"); envEngine != "" {
+		e = envEngine
+	}
+
 	switch e {
 	case dockerEngine:
 		binaryPath, err := execabs.LookPath(dockerEngine)
@@ -50,34 +112,62 @@ func MakeEngine(e string) (Engine, error) {
 		if err != nil {
 			return Engine{}, fmt.Errorf("podman binary not found in PATH")
 		}
-		return Engine{Name: podmanEngine, Binary: binaryPath}, nil
-	case "":
-		binaryPath := "/usr/bin/docker"
-		/*binaryPath, err := execabs.LookPath(dockerEngine)
-		log.Infof("Docker error: ", err)
+		url, identity, err := resolvePodmanConnection(remote)
+		if err != nil {
+			return Engine{}, err
+		}
+		return Engine{Name: podmanEngine, Binary: binaryPath, URL: url, Identity: identity}, nil
+	case buildahEngine:
+		binaryPath, err := execabs.LookPath(buildahEngine)
 		if err != nil {
-			// check for podman engine
-			binaryPath, err := execabs.LookPath(podmanEngine)
+			return Engine{}, fmt.Errorf("buildah binary not found in PATH")
+		}
+		return Engine{Name: buildahEngine, Binary: binaryPath}, nil
+	case "":
+		if url, identity, err := resolvePodmanConnection(remote); err == nil && url != "" {
+			if binaryPath, lookErr := execabs.LookPath(podmanEngine); lookErr == nil {
+				return Engine{Name: podmanEngine, Binary: binaryPath, URL: url, Identity: identity}, nil
+			}
+		}
+
+		if binaryPath, lookErr := lookupDockerBinary(); lookErr == nil {
+			// docker binary found, check if it is an alias to podman or nerdctl
+			// (i.e. "podman-docker" or a nerdctl shim) before trusting the name
+			out, err := execabs.Command(binaryPath, "--version").Output()
 			if err != nil {
-				return Engine{}, fmt.Errorf("engine binary not found in PATH")
+				return Engine{}, fmt.Errorf("could not detect engine version: %s", out)
+			}
+			name, err := classifyEngineFromVersion(out)
+			if err != nil {
+				return Engine{}, err
+			}
+			switch name {
+			case podmanEngine:
+				url, identity, err := resolvePodmanConnection(remote)
+				if err != nil {
+					return Engine{}, err
+				}
+				return Engine{Name: podmanEngine, Binary: binaryPath, URL: url, Identity: identity}, nil
+			case nerdctlEngine:
+				return Engine{Name: nerdctlEngine, Binary: binaryPath}, nil
+			default:
+				return Engine{Name: dockerEngine, Binary: binaryPath}, nil
 			}
-			return Engine{Name: podmanEngine, Binary: binaryPath}, nil
-		}*/
-		// docker binary found, check if it is an alias to podman
-		// if "docker" comes from an alias (i.e. "podman-docker") should not contain the "docker" string
-		out, err := execabs.Command(binaryPath, "--version").Output()
-		if err != nil {
-			return Engine{}, fmt.Errorf("could not detect engine version: %s", out)
 		}
-		lout := strings.ToLower(string(out))
-		switch {
-		case strings.Contains(lout, dockerEngine):
-			return Engine{Name: dockerEngine, Binary: binaryPath}, nil
-		case strings.Contains(lout, podmanEngine):
-			return Engine{Name: podmanEngine, Binary: binaryPath}, nil
-		default:
-			return Engine{}, fmt.Errorf("could not detect engine version: %s", out)
+
+		if binaryPath, err := execabs.LookPath(podmanEngine); err == nil {
+			url, identity, err := resolvePodmanConnection(remote)
+			if err != nil {
+				return Engine{}, err
+			}
+			return Engine{Name: podmanEngine, Binary: binaryPath, URL: url, Identity: identity}, nil
+		}
+
+		if binaryPath, err := execabs.LookPath(nerdctlEngine); err == nil {
+			return Engine{Name: nerdctlEngine, Binary: binaryPath}, nil
 		}
+
+		return Engine{}, fmt.Errorf("engine binary not found in PATH")
 	case kubernetesEngine:
 		// Try establishing a connection to Kubernetes cluster
 		err := checkKubernetesClient()
@@ -90,3 +180,113 @@ func MakeEngine(e string) (Engine, error) {
 		return Engine{}, errors.New("unsupported container engine")
 	}
 }
+
+// classifyEngineFromVersion maps the output of `<binary> --version` to the
+// concrete engine it identifies. podman and nerdctl are checked before
+// docker since both ship drop-in "docker" aliases (podman-docker, nerdctl's
+// docker-compat shim) whose --version output still names the real engine.
+func classifyEngineFromVersion(out []byte) (string, error) {
+	lout := strings.ToLower(string(out))
+	switch {
+	case strings.Contains(lout, podmanEngine):
+		return podmanEngine, nil
+	case strings.Contains(lout, nerdctlEngine):
+		return nerdctlEngine, nil
+	case strings.Contains(lout, dockerEngine):
+		return dockerEngine, nil
+	default:
+		return "", fmt.Errorf("could not detect engine version: %s", out)
+	}
+}
+
+// lookupDockerBinary resolves the docker binary, falling back to the
+// well-known locations docker isn't always on PATH from: Docker Desktop's
+// ~/.docker/bin and Homebrew/manual installs under /usr/local/bin.
+func lookupDockerBinary() (string, error) {
+	if binaryPath, err := execabs.LookPath(dockerEngine); err == nil {
+		return binaryPath, nil
+	}
+
+	var candidates []string
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, filepath.Join(home, ".docker", "bin", "docker"))
+	}
+	candidates = append(candidates, "/usr/local/bin/docker")
+
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("docker binary not found in PATH")
+}
+
+// resolvePodmanConnection resolves the remote podman service destination to
+// use, if any: --podman-host first, then PODMAN_HOST/CONTAINER_HOST, then a
+// named --podman-connection looked up in containers.conf.
+func resolvePodmanConnection(remote PodmanRemote) (url string, identity string, err error) {
+	if remote.Host != "" {
+		return remote.Host, "", nil
+	}
+	if host := os.Getenv("PODMAN_HOST"); host != "" {
+		return host, "", nil
+	}
+	if host := os.Getenv("CONTAINER_HOST"); host != "" {
+		return host, "", nil
+	}
+	if remote.Connection == "" {
+		return "", "", nil
+	}
+
+	return lookupContainersConfConnection(remote.Connection)
+}
+
+// lookupContainersConfConnection reads the [engine.service_destinations.NAME]
+// URI/Identity out of ~/.config/containers/containers.conf for the given
+// connection name.
+func lookupContainersConfConnection(name string) (url string, identity string, err error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", fmt.Errorf("could not resolve podman connection %q: %v", name, err)
+	}
+
+	path := filepath.Join(home, ".config", "containers", "containers.conf")
+	f, err := os.Open(path)
+	if err != nil {
+		return "", "", fmt.Errorf("could not resolve podman connection %q: %v", name, err)
+	}
+	defer f.Close()
+
+	section := fmt.Sprintf("[engine.service_destinations.%s]", name)
+	inSection := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "["):
+			inSection = line == section
+		case inSection && strings.HasPrefix(line, "URI"):
+			url = containersConfValue(line)
+		case inSection && strings.HasPrefix(line, "Identity"):
+			identity = containersConfValue(line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", "", fmt.Errorf("could not resolve podman connection %q: %v", name, err)
+	}
+	if url == "" {
+		return "", "", fmt.Errorf("podman connection %q not found in %s", name, path)
+	}
+
+	return url, identity, nil
+}
+
+// containersConfValue extracts the quoted value from a `Key = "value"` TOML line
+func containersConfValue(line string) string {
+	parts := strings.SplitN(line, "=", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+	return strings.Trim(strings.TrimSpace(parts[1]), `"`)
+}
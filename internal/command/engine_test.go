@@ -0,0 +1,77 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeExecutable creates a minimal executable shell script at path,
+// creating any missing parent directories.
+func writeExecutable(t *testing.T, path string) error {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte("#!/bin/sh\n"), 0755)
+}
+
+func TestClassifyEngineFromVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want string
+	}{
+		{"docker", "Docker version 24.0.7, build afdd53b", dockerEngine},
+		{"podman docker alias", "podman version 4.9.3", podmanEngine},
+		{"podman-docker shim", "podman-docker emulation layer, version 4.9.3", podmanEngine},
+		{"nerdctl", "nerdctl version 1.7.6", nerdctlEngine},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := classifyEngineFromVersion([]byte(tt.out))
+			if err != nil {
+				t.Fatalf("classifyEngineFromVersion(%q) returned error: %v", tt.out, err)
+			}
+			if got != tt.want {
+				t.Errorf("classifyEngineFromVersion(%q) = %q, want %q", tt.out, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyEngineFromVersionUnknown(t *testing.T) {
+	if _, err := classifyEngineFromVersion([]byte("some unrelated tool, version 1.0")); err == nil {
+		t.Fatal("classifyEngineFromVersion returned no error for unrecognized output")
+	}
+}
+
+func TestLookupDockerBinaryDockerDesktopFallback(t *testing.T) {
+	t.Setenv("PATH", "")
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dockerDesktopPath := filepath.Join(home, ".docker", "bin", "docker")
+	if err := writeExecutable(t, dockerDesktopPath); err != nil {
+		t.Fatalf("could not set up fake Docker Desktop binary: %v", err)
+	}
+
+	binaryPath, err := lookupDockerBinary()
+	if err != nil {
+		t.Fatalf("lookupDockerBinary() returned error: %v", err)
+	}
+	if binaryPath != dockerDesktopPath {
+		t.Errorf("lookupDockerBinary() = %q, want %q", binaryPath, dockerDesktopPath)
+	}
+}
+
+func TestLookupDockerBinaryNotFound(t *testing.T) {
+	t.Setenv("PATH", "")
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := lookupDockerBinary(); err == nil {
+		t.Fatal("lookupDockerBinary() returned no error when no docker binary exists")
+	}
+}
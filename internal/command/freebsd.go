@@ -2,10 +2,14 @@ package command
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/faradey/fyne-cross/internal/log"
 	"github.com/faradey/fyne-cross/internal/volume"
+	"golang.org/x/sys/execabs"
 )
 
 const (
@@ -15,17 +19,39 @@ const (
 	freebsdImageAmd64 = "fyneio/fyne-cross-images:freebsd-amd64"
 	// freebsdImageArm64 is the fyne-cross image for the FreeBSD OS arm64 arch
 	freebsdImageArm64 = "fyneio/fyne-cross-images:freebsd-arm64"
+	// freebsdVersionDefault is the FreeBSD ABI version used when -freebsd-version is not set
+	freebsdVersionDefault = "12"
 )
 
 var (
 	// freebsdArchSupported defines the supported target architectures on freebsd
 	freebsdArchSupported = []Architecture{ArchAmd64, ArchArm64}
+	// freebsdVersionSupported defines the supported FreeBSD ABI versions
+	freebsdVersionSupported = []string{"12", "13", "14"}
 )
 
 // FreeBSD build and package the fyne app for the freebsd OS
 type freeBSD struct {
 	Images         []containerImage
 	defaultContext Context
+
+	// manifest is the name of the OCI manifest list to create from the
+	// per-architecture artifacts, set via the -manifest flag. Empty disables it.
+	manifest string
+	// built tracks the artifact produced for each architecture so Run can
+	// assemble the manifest list once every arch has finished building
+	built []freebsdArtifact
+
+	// overlayTags are the transient -containerfile overlay images built for
+	// this invocation (see buildContainerfileOverlay), removed once Run finishes
+	overlayTags []string
+}
+
+// freebsdArtifact pairs the manifest-able image tag built for an architecture
+// (see buildArtifactImage) with that architecture
+type freebsdArtifact struct {
+	arch     Architecture
+	artifact string
 }
 
 var _ platformBuilder = (*freeBSD)(nil)
@@ -45,7 +71,18 @@ func (cmd *freeBSD) Description() string {
 }
 
 func (cmd *freeBSD) Run() error {
-	return commonRun(cmd.defaultContext, cmd.Images, cmd)
+	defer closeImages(cmd.Images)
+	defer cmd.removeOverlayImages()
+
+	if err := commonRun(cmd.defaultContext, cmd.Images, cmd); err != nil {
+		return err
+	}
+
+	if cmd.manifest == "" {
+		return nil
+	}
+
+	return cmd.createManifestList()
 }
 
 // Parse parses the arguments and set the usage for the command
@@ -60,6 +97,11 @@ func (cmd *freeBSD) Parse(args []string) error {
 		TargetArch:  &targetArchFlag{runtime.GOARCH},
 	}
 	flagSet.Var(flags.TargetArch, "arch", fmt.Sprintf(`List of target architecture to build separated by comma. Supported arch: %s`, freebsdArchSupported))
+	flagSet.StringVar(&flags.Manifest, "manifest", "", "Name of the OCI manifest list to create from the per-architecture artifacts")
+	flagSet.StringVar(&flags.FreeBSDVersion, "freebsd-version", freebsdVersionDefault, fmt.Sprintf("FreeBSD ABI version to target. Supported: %s", freebsdVersionSupported))
+	flagSet.StringVar(&flags.Containerfile, "containerfile", "", "Path to a Containerfile appended to the base image, to add extra native libs to the build environment")
+	flagSet.StringVar(&flags.PodmanHost, "podman-host", "", "Remote podman service destination (e.g. ssh://user@host/run/user/1000/podman/podman.sock). Overrides PODMAN_HOST/CONTAINER_HOST")
+	flagSet.StringVar(&flags.PodmanConnection, "podman-connection", "", "Name of a connection in ~/.config/containers/containers.conf to use as the remote podman service destination")
 
 	flagSet.Usage = cmd.Usage
 	flagSet.Parse(args)
@@ -104,9 +146,79 @@ func (cmd *freeBSD) Build(image containerImage) (string, error) {
 			volume.JoinPathContainer(cmd.defaultContext.TmpDirContainer(), image.ID(), packageName),
 			"--strip-components=3", "usr/local/bin"})
 
+	if cmd.manifest != "" {
+		binHostDir := volume.JoinPathHost(cmd.defaultContext.BinDirHost(), image.ID())
+		imageTag, err := buildArtifactImage(cmd.defaultContext.Engine, cmd.manifest, Architecture(image.ID()), binHostDir)
+		if err != nil {
+			return "", fmt.Errorf("could not build manifest artifact image for %s: %v", image.ID(), err)
+		}
+		cmd.built = append(cmd.built, freebsdArtifact{arch: Architecture(image.ID()), artifact: imageTag})
+	}
+
 	return packageName, nil
 }
 
+// createManifestList assembles the artifacts built for each requested
+// architecture into a single OCI image index, so the result can be
+// distributed and pulled as one reference. It fails atomically: if adding
+// any arch fails, the manifest list is not pushed.
+//
+// Only podman and buildah are supported: both resolve `manifest add`
+// references against the local image store, which is where
+// buildArtifactImage leaves the per-arch artifact images. docker's
+// `manifest` subcommands only resolve references from a registry, so a
+// locally-built, unpushed artifact image can never be added - there is no
+// local-store path to make `-manifest` work on docker.
+func (cmd *freeBSD) createManifestList() error {
+	engine := cmd.defaultContext.Engine
+	if !engine.IsPodman() && !engine.IsBuildah() {
+		return fmt.Errorf("-manifest requires the podman or buildah engine (got %s): docker's manifest commands only resolve registry references, not the local store", engine)
+	}
+
+	createArgs := append(engine.RemoteArgs(), "manifest", "create", cmd.manifest)
+	if out, err := execabs.Command(engine.Binary, createArgs...).CombinedOutput(); err != nil {
+		return fmt.Errorf("could not create manifest list %s: %s: %v", cmd.manifest, out, err)
+	}
+
+	for _, built := range cmd.built {
+		addArgs := append([]string{}, engine.RemoteArgs()...)
+		addArgs = append(addArgs, "manifest", "add",
+			"--os", freebsdOS, "--arch", string(built.arch),
+			cmd.manifest, built.artifact,
+		)
+
+		if out, err := execabs.Command(engine.Binary, addArgs...).CombinedOutput(); err != nil {
+			return fmt.Errorf("could not add %s artifact to manifest list %s: %s: %v", built.arch, cmd.manifest, out, err)
+		}
+	}
+
+	pushArgs := append(engine.RemoteArgs(), "manifest", "push", cmd.manifest, fmt.Sprintf("docker://%s", cmd.manifest))
+	if out, err := execabs.Command(engine.Binary, pushArgs...).CombinedOutput(); err != nil {
+		return fmt.Errorf("could not push manifest list %s: %s: %v", cmd.manifest, out, err)
+	}
+
+	return nil
+}
+
+// removeOverlayImages untags the transient -containerfile overlay images
+// built for this invocation, so they don't accumulate as dangling local
+// images across runs. Failures are logged, not returned, since the build
+// itself already succeeded or failed by the time this runs.
+func (cmd *freeBSD) removeOverlayImages() {
+	if len(cmd.overlayTags) == 0 {
+		return
+	}
+
+	engine := cmd.defaultContext.Engine
+
+	for _, tag := range cmd.overlayTags {
+		rmiArgs := append(engine.RemoteArgs(), "rmi", tag)
+		if out, err := execabs.Command(engine.Binary, rmiArgs...).CombinedOutput(); err != nil {
+			log.Infof("[i] could not remove transient -containerfile overlay image %s: %s: %v", tag, out, err)
+		}
+	}
+}
+
 // Usage displays the command usage
 func (cmd *freeBSD) Usage() {
 	data := struct {
@@ -135,6 +247,190 @@ type freebsdFlags struct {
 
 	// TargetArch represents a list of target architecture to build on separated by comma
 	TargetArch *targetArchFlag
+
+	// Manifest is the name of the OCI manifest list to create from the
+	// per-architecture artifacts once every arch has built. Empty disables it.
+	//
+	// This is freebsd-only, not a CommonFlag: assembling the list requires
+	// buildArtifactImage to package each arch's bin output into a real image,
+	// and only freeBSD.Build does that today. linux/darwin/windows would need
+	// the same per-arch packaging step before -manifest could be driven from
+	// commonRun instead of freeBSD.Run.
+	Manifest string
+
+	// FreeBSDVersion is the target FreeBSD ABI version (12, 13 or 14)
+	FreeBSDVersion string
+
+	// Containerfile is the path to a user Containerfile appended to the base
+	// fyne-cross-images, to extend the build environment with extra native libs.
+	// Empty disables it.
+	//
+	// This is freebsd-only, not a CommonFlag: the overlay is built in
+	// setupContainerImages on top of freebsdImageTag's fyne-cross-images tag,
+	// or on the -image override when set; the other builders have no
+	// equivalent step.
+	Containerfile string
+
+	// PodmanHost is the remote podman service destination, when driving a
+	// remote/rootless podman instead of a local one. Empty disables it.
+	PodmanHost string
+
+	// PodmanConnection names a connection in containers.conf to resolve the
+	// remote podman service destination from. Ignored if PodmanHost is set.
+	PodmanConnection string
+}
+
+// freebsdImageTag returns the fyne-cross-images tag for arch at the given
+// FreeBSD ABI version, keeping the original image tag without a version
+// suffix for the default version 12 so existing pulls/caches keep working.
+func freebsdImageTag(arch Architecture, version string) string {
+	base := map[Architecture]string{ArchAmd64: freebsdImageAmd64, ArchArm64: freebsdImageArm64}[arch]
+	if version == freebsdVersionDefault {
+		return base
+	}
+	return fmt.Sprintf("%s-%s", base, version)
+}
+
+// freebsdClangTarget returns the clang target triple for arch at the given FreeBSD ABI version
+func freebsdClangTarget(arch Architecture, version string) string {
+	triple := map[Architecture]string{ArchAmd64: "x86_64-unknown-freebsd", ArchArm64: "aarch64-unknown-freebsd"}[arch]
+	return triple + version
+}
+
+// buildContainerfileOverlay builds a transient local image that layers the
+// user's Containerfile at containerfilePath on top of baseImage, tagging it
+// for this invocation only, and returns the derived tag. This gives users an
+// extension point for extra native libs without forking fyne-cross-images.
+// The caller is responsible for untagging the returned tag once the
+// invocation is done (see freeBSD.removeOverlayImages).
+func buildContainerfileOverlay(engine Engine, baseImage, containerfilePath string) (string, error) {
+	overlay, err := os.ReadFile(containerfilePath)
+	if err != nil {
+		return "", fmt.Errorf("could not read -containerfile %s: %v", containerfilePath, err)
+	}
+
+	buildDir, err := os.MkdirTemp("", "fyne-cross-containerfile-")
+	if err != nil {
+		return "", fmt.Errorf("could not create build context for -containerfile: %v", err)
+	}
+	defer os.RemoveAll(buildDir)
+
+	containerfile := fmt.Sprintf("FROM %s\n%s", baseImage, string(overlay))
+	if err := os.WriteFile(filepath.Join(buildDir, "Containerfile"), []byte(containerfile), 0644); err != nil {
+		return "", fmt.Errorf("could not write build context for -containerfile: %v", err)
+	}
+
+	// filepath.Base(buildDir) carries MkdirTemp's random suffix, so concurrent
+	// invocations building the same base image don't collide on the same tag
+	tag := fmt.Sprintf("fyne-cross-local/%s-%s", sanitizeImageTag(baseImage), filepath.Base(buildDir))
+
+	buildCmd := "build"
+	if engine.IsBuildah() {
+		buildCmd = "bud"
+	}
+
+	cmdArgs := append(engine.RemoteArgs(), buildCmd, "-f", "Containerfile", "-t", tag, buildDir)
+	out, err := execabs.Command(engine.Binary, cmdArgs...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("could not build -containerfile overlay on %s: %s: %v", baseImage, out, err)
+	}
+
+	return tag, nil
+}
+
+// buildArtifactImage packages the extracted binary at binHostDir into a
+// minimal `FROM scratch` image tagged "<manifest>-<arch>", giving
+// createManifestList a real image reference to add to the manifest list
+// instead of the packaged tarball name, which `manifest add` cannot resolve.
+// The image is built from a copy of binHostDir in a temp directory, like
+// buildContainerfileOverlay, so the published bin output isn't polluted with
+// the generated Containerfile/.dockerignore.
+//
+// It is called from freeBSD.Build for each arch, before Run reaches
+// createManifestList's engine gate. The --os/--arch build flags it passes
+// are podman and buildah flags; on other engines the build is not checked
+// up front and fails here with the engine's own error.
+func buildArtifactImage(engine Engine, manifest string, arch Architecture, binHostDir string) (string, error) {
+	buildDir, err := os.MkdirTemp("", "fyne-cross-manifest-artifact-")
+	if err != nil {
+		return "", fmt.Errorf("could not create build context for manifest artifact image: %v", err)
+	}
+	defer os.RemoveAll(buildDir)
+
+	if err := copyTree(binHostDir, buildDir); err != nil {
+		return "", fmt.Errorf("could not stage manifest artifact image context: %v", err)
+	}
+
+	containerfile := "FROM scratch\nCOPY . /\n"
+	if err := os.WriteFile(filepath.Join(buildDir, "Containerfile"), []byte(containerfile), 0644); err != nil {
+		return "", fmt.Errorf("could not write manifest artifact image context: %v", err)
+	}
+	// Keep the generated build files themselves out of the scratch image
+	if err := os.WriteFile(filepath.Join(buildDir, ".dockerignore"), []byte("Containerfile\n.dockerignore\n"), 0644); err != nil {
+		return "", fmt.Errorf("could not write manifest artifact image context: %v", err)
+	}
+
+	tag := fmt.Sprintf("%s-%s", sanitizeImageTag(manifest), arch)
+
+	buildCmd := "build"
+	if engine.IsBuildah() {
+		buildCmd = "bud"
+	}
+
+	cmdArgs := append([]string{}, engine.RemoteArgs()...)
+	cmdArgs = append(cmdArgs, buildCmd, "--os", freebsdOS, "--arch", string(arch), "-f", "Containerfile", "-t", tag, buildDir)
+
+	out, err := execabs.Command(engine.Binary, cmdArgs...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("could not build manifest artifact image %s: %s: %v", tag, out, err)
+	}
+
+	return tag, nil
+}
+
+// copyTree copies the regular files and directories under src into dst,
+// which must already exist, preserving each entry's mode
+func copyTree(src, dst string) error {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		srcPath := filepath.Join(src, entry.Name())
+		dstPath := filepath.Join(dst, entry.Name())
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if err := os.Mkdir(dstPath, info.Mode()); err != nil {
+				return err
+			}
+			if err := copyTree(srcPath, dstPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		data, err := os.ReadFile(srcPath)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(dstPath, data, info.Mode()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sanitizeImageTag turns an image reference into a valid tag component
+func sanitizeImageTag(image string) string {
+	replacer := strings.NewReplacer("/", "-", ":", "-")
+	return replacer.Replace(image)
 }
 
 // setupContainerImages returns the command context for a freebsd target
@@ -144,12 +440,37 @@ func (cmd *freeBSD) setupContainerImages(flags *freebsdFlags, args []string) err
 		return fmt.Errorf("could not make build context for %s OS: %s", freebsdOS, err)
 	}
 
+	version := flags.FreeBSDVersion
+	if version == "" {
+		version = freebsdVersionDefault
+	}
+	supported := false
+	for _, v := range freebsdVersionSupported {
+		if v == version {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return fmt.Errorf("unsupported -freebsd-version %q: supported versions are %s", version, freebsdVersionSupported)
+	}
+
 	ctx, err := makeDefaultContext(flags.CommonFlags, args)
 	if err != nil {
 		return err
 	}
 
+	if ctx.Engine.IsPodman() && (flags.PodmanHost != "" || flags.PodmanConnection != "") {
+		url, identity, err := resolvePodmanConnection(PodmanRemote{Host: flags.PodmanHost, Connection: flags.PodmanConnection})
+		if err != nil {
+			return err
+		}
+		ctx.Engine.URL = url
+		ctx.Engine.Identity = identity
+	}
+
 	cmd.defaultContext = ctx
+	cmd.manifest = flags.Manifest
 	runner, err := newContainerEngine(ctx)
 	if err != nil {
 		return err
@@ -158,12 +479,25 @@ func (cmd *freeBSD) setupContainerImages(flags *freebsdFlags, args []string) err
 	for _, arch := range targetArch {
 		var image containerImage
 
+		// Resolve the base image (honouring -image) before building the
+		// -containerfile overlay on top of it, and use the overlay tag
+		// unconditionally from there on - otherwise -image would silently
+		// discard the overlay further down while it's still built and rmi'd.
+		imageTag := overrideDockerImage(flags.CommonFlags, freebsdImageTag(arch, version))
+		if flags.Containerfile != "" {
+			imageTag, err = buildContainerfileOverlay(ctx.Engine, imageTag, flags.Containerfile)
+			if err != nil {
+				return err
+			}
+			cmd.overlayTags = append(cmd.overlayTags, imageTag)
+		}
+
 		switch arch {
 		case ArchAmd64:
-			image = runner.createContainerImage(arch, freebsdOS, overrideDockerImage(flags.CommonFlags, freebsdImageAmd64))
+			image = runner.createContainerImage(arch, freebsdOS, imageTag)
 			image.SetEnv("GOARCH", "amd64")
-			image.SetEnv("CC", "clang --sysroot=/freebsd --target=x86_64-unknown-freebsd12")
-			image.SetEnv("CXX", "clang++ --sysroot=/freebsd --target=x86_64-unknown-freebsd12")
+			image.SetEnv("CC", "clang --sysroot=/freebsd --target="+freebsdClangTarget(arch, version))
+			image.SetEnv("CXX", "clang++ --sysroot=/freebsd --target="+freebsdClangTarget(arch, version))
 			if runtime.GOARCH == string(ArchArm64) {
 				if v, ok := ctx.Env["CGO_LDFLAGS"]; ok {
 					image.SetEnv("CGO_LDFLAGS", v+" -fuse-ld=lld")
@@ -172,15 +506,15 @@ func (cmd *freeBSD) setupContainerImages(flags *freebsdFlags, args []string) err
 				}
 			}
 		case ArchArm64:
-			image = runner.createContainerImage(arch, freebsdOS, overrideDockerImage(flags.CommonFlags, freebsdImageArm64))
+			image = runner.createContainerImage(arch, freebsdOS, imageTag)
 			image.SetEnv("GOARCH", "arm64")
 			if v, ok := ctx.Env["CGO_LDFLAGS"]; ok {
 				image.SetEnv("CGO_LDFLAGS", v+" -fuse-ld=lld")
 			} else {
 				image.SetEnv("CGO_LDFLAGS", "-fuse-ld=lld")
 			}
-			image.SetEnv("CC", "clang --sysroot=/freebsd --target=aarch64-unknown-freebsd12")
-			image.SetEnv("CXX", "clang++ --sysroot=/freebsd --target=aarch64-unknown-freebsd12")
+			image.SetEnv("CC", "clang --sysroot=/freebsd --target="+freebsdClangTarget(arch, version))
+			image.SetEnv("CXX", "clang++ --sysroot=/freebsd --target="+freebsdClangTarget(arch, version))
 		}
 		image.SetEnv("GOOS", "freebsd")
 